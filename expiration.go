@@ -0,0 +1,120 @@
+package kache
+
+import (
+	"errors"
+	"time"
+)
+
+// NoExpiration marks an item as never expiring.
+const NoExpiration = time.Duration(-1)
+
+// DefaultExpiration tells Set (and the methods built on it) to use the
+// cache's configured default expiration, set via WithDefaultExpiration.
+const DefaultExpiration = time.Duration(0)
+
+// ErrKeyExists is returned by Add when the key is already present and
+// unexpired.
+var ErrKeyExists = errors.New("kache: key already exists")
+
+// ErrKeyNotFound is returned by Replace and Update when the key is absent
+// or expired.
+var ErrKeyNotFound = errors.New("kache: key not found")
+
+// Add adds a value to the cache only if the key is not already present, or
+// is present but expired. Returns ErrKeyExists otherwise.
+func (c *Kache[K, V]) Add(key K, value V, expiry time.Duration) error {
+	s := c.shardFor(key)
+
+	var overflow []K
+	s.mu.Lock()
+	if it, found := s.data[key]; found && !it.isExpired() {
+		s.mu.Unlock()
+		return ErrKeyExists
+	}
+	s.data[key] = item[V]{
+		value:  value,
+		expiry: c.expiryTime(expiry),
+	}
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+	return nil
+}
+
+// Replace updates the value for a key only if it is already present and
+// unexpired. Returns ErrKeyNotFound otherwise.
+func (c *Kache[K, V]) Replace(key K, value V, expiry time.Duration) error {
+	s := c.shardFor(key)
+
+	var overflow []K
+	s.mu.Lock()
+	it, found := s.data[key]
+	if !found || it.isExpired() {
+		s.mu.Unlock()
+		return ErrKeyNotFound
+	}
+	s.data[key] = item[V]{
+		value:  value,
+		expiry: c.expiryTime(expiry),
+	}
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+	return nil
+}
+
+// Update replaces the value stored for key while preserving its original
+// expiry, returning ErrKeyNotFound if the key is absent or expired.
+func (c *Kache[K, V]) Update(key K, value V) error {
+	s := c.shardFor(key)
+
+	var overflow []K
+	s.mu.Lock()
+	it, found := s.data[key]
+	if !found || it.isExpired() {
+		s.mu.Unlock()
+		return ErrKeyNotFound
+	}
+	s.data[key] = item[V]{
+		value:  value,
+		expiry: it.expiry,
+	}
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+	return nil
+}
+
+// GetWithExpiration retrieves a value from the cache along with its
+// absolute expiry time. The returned time is zero for items with
+// NoExpiration.
+func (c *Kache[K, V]) GetWithExpiration(key K) (V, time.Time, bool) {
+	s := c.shardFor(key)
+
+	var overflow []K
+	s.mu.RLock()
+	it, found := s.data[key]
+	expired := found && it.isExpired()
+	if found && !expired && c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.RUnlock()
+
+	c.evictOverflow(overflow)
+
+	if !found || expired {
+		var zero V
+		return zero, time.Time{}, false
+	}
+
+	return it.value, it.expiry, true
+}
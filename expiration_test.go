@@ -0,0 +1,182 @@
+package kache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	t.Run("Succeeds when the key is absent", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if err := cache.Add("key1", 42, time.Minute); err != nil {
+			t.Fatalf("Expected Add to succeed, got %v", err)
+		}
+		value, exists := cache.Get("key1")
+		if !exists || value != 42 {
+			t.Errorf("Expected key1=42, got %v %v", value, exists)
+		}
+	})
+
+	t.Run("Fails when the key already exists and is unexpired", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, time.Minute)
+		if err := cache.Add("key1", 99, time.Minute); !errors.Is(err, ErrKeyExists) {
+			t.Errorf("Expected ErrKeyExists, got %v", err)
+		}
+	})
+
+	t.Run("Succeeds when the existing key has expired", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, 10*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		if err := cache.Add("key1", 99, time.Minute); err != nil {
+			t.Fatalf("Expected Add to succeed over an expired key, got %v", err)
+		}
+		value, _ := cache.Get("key1")
+		if value != 99 {
+			t.Errorf("Expected key1=99, got %v", value)
+		}
+	})
+}
+
+func TestReplace(t *testing.T) {
+	t.Run("Fails when the key is absent", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if err := cache.Replace("missing", 1, time.Minute); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Succeeds when the key is present", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, time.Minute)
+		if err := cache.Replace("key1", 99, time.Minute); err != nil {
+			t.Fatalf("Expected Replace to succeed, got %v", err)
+		}
+		value, _ := cache.Get("key1")
+		if value != 99 {
+			t.Errorf("Expected key1=99, got %v", value)
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("Preserves the original expiry", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, time.Minute)
+		_, originalExpiry, _ := cache.GetWithExpiration("key1")
+
+		if err := cache.Update("key1", 99); err != nil {
+			t.Fatalf("Expected Update to succeed, got %v", err)
+		}
+
+		value, newExpiry, exists := cache.GetWithExpiration("key1")
+		if !exists || value != 99 {
+			t.Errorf("Expected key1=99, got %v %v", value, exists)
+		}
+		if diff := newExpiry.Sub(originalExpiry); diff < -time.Millisecond || diff > time.Millisecond {
+			t.Errorf("Expected expiry to be preserved, got %v want %v", newExpiry, originalExpiry)
+		}
+	})
+
+	t.Run("Fails when the key is absent", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if err := cache.Update("missing", 1); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Preserves NoExpiration", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, NoExpiration)
+		if err := cache.Update("key1", 99); err != nil {
+			t.Fatalf("Expected Update to succeed, got %v", err)
+		}
+
+		_, expiry, exists := cache.GetWithExpiration("key1")
+		if !exists {
+			t.Error("Expected key1 to still exist")
+		}
+		if !expiry.IsZero() {
+			t.Errorf("Expected expiry to remain zero, got %v", expiry)
+		}
+	})
+}
+
+func TestGetWithExpiration(t *testing.T) {
+	t.Run("Returns the absolute expiry time", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		before := time.Now()
+		cache.Set("key1", 42, time.Minute)
+
+		value, expiry, exists := cache.GetWithExpiration("key1")
+		if !exists || value != 42 {
+			t.Errorf("Expected key1=42, got %v %v", value, exists)
+		}
+		if expiry.Before(before.Add(time.Minute)) {
+			t.Errorf("Expected expiry at least a minute out, got %v", expiry)
+		}
+	})
+
+	t.Run("Returns a zero time for NoExpiration", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("key1", 42, NoExpiration)
+		_, expiry, exists := cache.GetWithExpiration("key1")
+		if !exists {
+			t.Error("Expected key1 to exist")
+		}
+		if !expiry.IsZero() {
+			t.Errorf("Expected zero expiry, got %v", expiry)
+		}
+	})
+
+	t.Run("Returns false for a missing key", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		_, _, exists := cache.GetWithExpiration("missing")
+		if exists {
+			t.Error("Expected missing key to not be found")
+		}
+	})
+}
+
+func TestDefaultExpiration(t *testing.T) {
+	t.Run("WithDefaultExpiration is used when Set is passed DefaultExpiration", func(t *testing.T) {
+		cache := New[string, int](WithDefaultExpiration[string, int](time.Minute))
+		defer cache.Close()
+
+		before := time.Now()
+		cache.Set("key1", 42, DefaultExpiration)
+
+		_, expiry, exists := cache.GetWithExpiration("key1")
+		if !exists {
+			t.Fatal("Expected key1 to exist")
+		}
+		if expiry.Before(before.Add(time.Minute)) {
+			t.Errorf("Expected expiry at least a minute out, got %v", expiry)
+		}
+	})
+}
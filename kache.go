@@ -1,24 +1,29 @@
 // Package kache provides a generic, thread-safe key-value cache implementation with expiration support.
 //
 // The package offers a simple interface (Kacher) and implementation (Kache) for caching arbitrary
-// key-value pairs with automatic cleanup of expired entries. The cache is safe for concurrent use
-// through sync.RWMutex protection.
+// key-value pairs with automatic cleanup of expired entries. The cache is safe for concurrent use,
+// and internally shards its storage across multiple locks to reduce contention.
 //
 // Example usage:
 //
 //	cache := kache.New[string, int]()
-//	cache.Set("key", 42)
+//	defer cache.Close()
+//	cache.Set("key", 42, time.Minute)
 //	value, exists := cache.Get("key")
 //
 // Key features:
 //   - Generic implementation supporting any comparable key type and any value type
-//   - Automatic cleanup of expired items
-//   - Thread-safe operations
+//   - Automatic cleanup of expired items via a stoppable background janitor
+//   - Sharded storage for reduced lock contention under concurrent load
 //   - Basic operations: Get, Set, Delete, Pop
-
 package kache
 
 import (
+	"container/list"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -39,93 +44,364 @@ type item[V any] struct {
 	expiry time.Time
 }
 
-// isExpired checks if the item has expired by comparing its expiry time
-// with the current time. Returns true if the item has expired.
+// isExpired reports whether the item has expired. A zero expiry means the
+// item never expires (see NoExpiration).
 func (i item[V]) isExpired() bool {
-	return i.expiry.After(time.Now())
+	if i.expiry.IsZero() {
+		return false
+	}
+	return !i.expiry.After(time.Now())
+}
+
+const (
+	// defaultShards is the number of shards a Kache is split into unless
+	// overridden with WithShards.
+	defaultShards = 32
+
+	// defaultCleanupInterval is how often the background janitor sweeps for
+	// expired items unless overridden with WithCleanupInterval.
+	defaultCleanupInterval = 5 * time.Second
+)
+
+// shard holds a partition of the cache's entries behind its own lock, so
+// operations on keys that hash to different shards don't contend.
+type shard[K comparable, V any] struct {
+	mu   sync.RWMutex
+	data map[K]item[V]
 }
 
 // Kache is a generic key-value cache implementation that supports basic operations
 // like Get, Set, Delete and Pop with keys of type K and values of type V.
 type Kache[K comparable, V any] struct {
-	mu   sync.RWMutex
-	data map[K]item[V]
+	shards []*shard[K, V]
+
+	cleanupInterval time.Duration
+	noJanitor       bool
+
+	ticker    *time.Ticker
+	stop      chan struct{}
+	closeOnce sync.Once
+
+	// LRU bookkeeping, only populated when maxEntries > 0.
+	maxEntries int
+	lruMu      sync.Mutex
+	list       *list.List
+	elems      map[K]*list.Element
+
+	onEvicted     func(K, V, EvictReason)
+	onEvictedBulk func([]Evicted[K, V])
+
+	defaultExpiration time.Duration
+
+	mergeOnLoad bool
 }
 
-// New creates a new Kache instance.
-func New[K comparable, V any]() *Kache[K, V] {
+// Option configures a Kache created via New.
+type Option[K comparable, V any] func(*Kache[K, V])
+
+// WithCleanupInterval sets how often the background janitor sweeps the cache
+// for expired items. The default is 5 seconds.
+func WithCleanupInterval[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.cleanupInterval = d
+	}
+}
+
+// WithShards sets the number of shards the cache's storage is split into.
+// Higher shard counts reduce lock contention under concurrent load at the
+// cost of a small amount of memory overhead. The default is 32.
+func WithShards[K comparable, V any](n int) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		if n > 0 {
+			c.shards = make([]*shard[K, V], n)
+		}
+	}
+}
+
+// WithNoJanitor disables the background cleanup goroutine. Expired items
+// are still treated as absent by Get and Pop, but they are only actually
+// removed from memory the next time they're accessed.
+func WithNoJanitor[K comparable, V any]() Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.noJanitor = true
+	}
+}
+
+// WithDefaultExpiration sets the expiry duration used whenever a caller
+// passes DefaultExpiration to Set (or an equivalent method). The default
+// is DefaultExpiration itself, i.e. items expire immediately unless told
+// otherwise.
+func WithDefaultExpiration[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.defaultExpiration = d
+	}
+}
+
+// New creates a new Kache instance. By default it starts a background
+// janitor goroutine that periodically removes expired items; call Close
+// when done with the cache to stop it, or pass WithNoJanitor to opt out.
+func New[K comparable, V any](opts ...Option[K, V]) *Kache[K, V] {
 	c := &Kache[K, V]{
-		data: make(map[K]item[V]),
+		cleanupInterval: defaultCleanupInterval,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.shards == nil {
+		c.shards = make([]*shard[K, V], defaultShards)
+	}
+	for i := range c.shards {
+		c.shards[i] = &shard[K, V]{data: make(map[K]item[V])}
+	}
+
+	if c.maxEntries > 0 {
+		c.list = list.New()
+		c.elems = make(map[K]*list.Element)
+	}
+
+	if !c.noJanitor {
+		c.stop = make(chan struct{})
+		c.ticker = time.NewTicker(c.cleanupInterval)
+		go c.runJanitor()
+
+		// Fall back to stopping the janitor when the cache is garbage
+		// collected, in case a caller forgets to call Close.
+		runtime.SetFinalizer(c, (*Kache[K, V]).Close)
+	}
+
+	return c
+}
+
+// runJanitor periodically deletes expired items until Close is called.
+func (c *Kache[K, V]) runJanitor() {
+	for {
+		select {
+		case <-c.ticker.C:
+			c.deleteExpired()
+		case <-c.stop:
+			return
+		}
 	}
+}
 
-	// Start a goroutine to periodically clean up expired items.
-	go func() {
-		for range time.Tick(5 * time.Second) {
-			c.mu.Lock()
-			for k, v := range c.data {
-				if v.isExpired() {
-					delete(c.data, k)
+// deleteExpired removes all expired items from every shard, evicting them
+// from the LRU list and firing eviction callbacks as configured.
+func (c *Kache[K, V]) deleteExpired() {
+	var evicted []Evicted[K, V]
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for k, v := range s.data {
+			if v.isExpired() {
+				delete(s.data, k)
+				if c.maxEntries > 0 {
+					c.removeFromLRU(k)
 				}
+				evicted = append(evicted, Evicted[K, V]{Key: k, Value: v.value, Reason: ReasonExpired})
 			}
-			c.mu.Unlock()
 		}
-	}()
+		s.mu.Unlock()
+	}
 
-	return c
+	c.fireEvicted(evicted)
+}
+
+// Close stops the background janitor goroutine, if one is running. It is
+// safe to call Close multiple times, and safe to call even if the cache
+// was created with WithNoJanitor.
+func (c *Kache[K, V]) Close() error {
+	c.closeOnce.Do(func() {
+		if c.ticker != nil {
+			c.ticker.Stop()
+		}
+		if c.stop != nil {
+			close(c.stop)
+		}
+		runtime.SetFinalizer(c, nil)
+	})
+	return nil
+}
+
+// fnvOffset64 and fnvPrime64 are the FNV-1a constants used by hashString
+// and hashUint64 below.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// shardHasherPool holds reusable FNV-1a hashers for keys that don't match
+// one of hashKey's fast paths, so hashing an unusual key type doesn't
+// allocate a fresh hasher on every call.
+var shardHasherPool = sync.Pool{
+	New: func() any { return fnv.New64a() },
+}
+
+// shardFor returns the shard responsible for key.
+func (c *Kache[K, V]) shardFor(key K) *shard[K, V] {
+	return c.shards[c.hashKey(key)%uint64(len(c.shards))]
+}
+
+// hashKey hashes key to route it to a shard. Strings and integers, by far
+// the most common key types, are hashed directly without fmt formatting or
+// a pooled hasher; anything else falls back to a pooled FNV-1a
+// hasher over the key's default string representation.
+func (c *Kache[K, V]) hashKey(key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return hashString(k)
+	case int:
+		return hashUint64(uint64(k))
+	case int8:
+		return hashUint64(uint64(k))
+	case int16:
+		return hashUint64(uint64(k))
+	case int32:
+		return hashUint64(uint64(k))
+	case int64:
+		return hashUint64(uint64(k))
+	case uint:
+		return hashUint64(uint64(k))
+	case uint8:
+		return hashUint64(uint64(k))
+	case uint16:
+		return hashUint64(uint64(k))
+	case uint32:
+		return hashUint64(uint64(k))
+	case uint64:
+		return hashUint64(k)
+	default:
+		h := shardHasherPool.Get().(hash.Hash64)
+		h.Reset()
+		fmt.Fprintf(h, "%v", key)
+		sum := h.Sum64()
+		shardHasherPool.Put(h)
+		return sum
+	}
+}
+
+// hashString computes the FNV-1a hash of s.
+func hashString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// hashUint64 computes the FNV-1a hash of n's little-endian byte representation.
+func hashUint64(n uint64) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < 8; i++ {
+		h ^= n & 0xff
+		h *= fnvPrime64
+		n >>= 8
+	}
+	return h
 }
 
 // Get retrieves a value from the cache.
 // Returns the value and a boolean indicating if the value was found.
 func (c *Kache[K, V]) Get(key K) (V, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	item, found := c.data[key]
+	s := c.shardFor(key)
 
-	if !found {
-		return item.value, false
+	var overflow []K
+	s.mu.Lock()
+	it, found := s.data[key]
+	expired := found && it.isExpired()
+	switch {
+	case expired:
+		delete(s.data, key)
+		if c.maxEntries > 0 {
+			c.removeFromLRU(key)
+		}
+	case found && c.maxEntries > 0:
+		overflow = c.touch(key)
 	}
+	s.mu.Unlock()
 
-	if item.isExpired() {
-		delete(c.data, key)
-		return item.value, false
+	c.evictOverflow(overflow)
+
+	if !found || expired {
+		var zero V
+		return zero, false
 	}
 
-	return item.value, found
+	return it.value, true
 }
 
-// Set adds a value to the cache with an optional expiry time.
-// If no expiry is provided, the value will not expire.
+// Set adds a value to the cache with the given expiry duration. Pass
+// NoExpiration for a value that never expires, or DefaultExpiration to use
+// the cache's configured default (see WithDefaultExpiration).
 func (c *Kache[K, V]) Set(key K, value V, expiry time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data[key] = item[V]{
+	s := c.shardFor(key)
+
+	var overflow []K
+	s.mu.Lock()
+	s.data[key] = item[V]{
 		value:  value,
-		expiry: time.Now().Add(expiry),
+		expiry: c.expiryTime(expiry),
+	}
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
 	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+}
+
+// expiryTime turns a duration passed to Set (or an equivalent method) into
+// an absolute expiry time, honoring NoExpiration and DefaultExpiration.
+func (c *Kache[K, V]) expiryTime(expiry time.Duration) time.Time {
+	if expiry == DefaultExpiration {
+		expiry = c.defaultExpiration
+	}
+	if expiry < 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(expiry)
 }
 
 // Delete removes a value from the cache.
 func (c *Kache[K, V]) Delete(key K) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	delete(c.data, key)
+	s := c.shardFor(key)
+
+	s.mu.Lock()
+	it, found := s.data[key]
+	delete(s.data, key)
+	if c.maxEntries > 0 {
+		c.removeFromLRU(key)
+	}
+	s.mu.Unlock()
+
+	if found {
+		c.fireEvicted([]Evicted[K, V]{{Key: key, Value: it.value, Reason: ReasonManual}})
+	}
 }
 
 // Pop removes a value from the cache and returns it.
 func (c *Kache[K, V]) Pop(key K) (V, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	item, found := c.data[key]
+	s := c.shardFor(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, found := s.data[key]
 
 	if !found {
-		return item.value, false
+		return it.value, false
 	}
 
-	delete(c.data, key)
+	delete(s.data, key)
+
+	if c.maxEntries > 0 {
+		c.removeFromLRU(key)
+	}
 
-	if item.isExpired() {
-		return item.value, false
+	if it.isExpired() {
+		var zero V
+		return zero, false
 	}
 
-	return item.value, found
+	return it.value, true
 }
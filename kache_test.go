@@ -104,16 +104,22 @@ func TestExpiration(t *testing.T) {
 		time.Sleep(6 * time.Second)
 
 		// Verify items were cleaned up
-		if len(cache.data) != 0 {
+		total := 0
+		for _, s := range cache.shards {
+			s.mu.RLock()
+			total += len(s.data)
+			s.mu.RUnlock()
+		}
+		if total != 0 {
 			t.Error("Expected all items to be cleaned up")
 		}
 	})
 
-	t.Run("Zero expiration", func(t *testing.T) {
+	t.Run("NoExpiration never expires", func(t *testing.T) {
 		cache := New[string, int]()
 
-		// Set item with zero expiration
-		cache.Set("key1", 42, 0)
+		// Set item with NoExpiration
+		cache.Set("key1", 42, NoExpiration)
 
 		// Wait some time
 		time.Sleep(100 * time.Millisecond)
@@ -121,7 +127,7 @@ func TestExpiration(t *testing.T) {
 		// Verify item still exists
 		_, exists := cache.Get("key1")
 		if !exists {
-			t.Error("Item with zero expiration should not expire")
+			t.Error("Item with NoExpiration should not expire")
 		}
 	})
 
@@ -176,6 +182,32 @@ func TestConcurrency(t *testing.T) {
 
 		wg.Wait()
 	})
+
+	t.Run("Get on an expired key does not clobber a concurrent Set", func(t *testing.T) {
+		cache := New[string, int](WithNoJanitor[string, int]())
+		defer cache.Close()
+
+		for i := 0; i < 20000; i++ {
+			cache.Set("key", 0, 1) // already expired by the time Get runs
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				cache.Get("key")
+			}()
+			go func() {
+				defer wg.Done()
+				cache.Set("key", 1, time.Minute)
+			}()
+			wg.Wait()
+
+			if _, exists := cache.Get("key"); !exists {
+				t.Fatalf("iteration %d: fresh Set was lost to a concurrent expired Get", i)
+			}
+			cache.Delete("key")
+		}
+	})
 }
 
 func TestDifferentTypes(t *testing.T) {
@@ -228,7 +260,7 @@ func TestItemExpiry(t *testing.T) {
 		// Test expired item
 		expiredItem := item[string]{
 			value:  "test",
-			expiry: time.Now().Add(-1 * time.Minute).UnixNano(),
+			expiry: time.Now().Add(-1 * time.Minute),
 		}
 		if !expiredItem.isExpired() {
 			t.Error("Item should be expired")
@@ -237,10 +269,73 @@ func TestItemExpiry(t *testing.T) {
 		// Test non-expired item
 		validItem := item[string]{
 			value:  "test",
-			expiry: time.Now().Add(1 * time.Minute).UnixNano(),
+			expiry: time.Now().Add(1 * time.Minute),
 		}
 		if validItem.isExpired() {
 			t.Error("Item should not be expired")
 		}
+
+		// Test item with NoExpiration (zero expiry)
+		neverItem := item[string]{value: "test"}
+		if neverItem.isExpired() {
+			t.Error("Item with a zero expiry should never be expired")
+		}
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("WithShards controls shard count", func(t *testing.T) {
+		cache := New[string, int](WithShards[string, int](4))
+
+		if len(cache.shards) != 4 {
+			t.Errorf("Expected 4 shards, got %d", len(cache.shards))
+		}
+		cache.Close()
+	})
+
+	t.Run("WithNoJanitor disables the background goroutine", func(t *testing.T) {
+		cache := New[string, int](WithNoJanitor[string, int]())
+
+		if cache.stop != nil || cache.ticker != nil {
+			t.Error("Expected no janitor state when WithNoJanitor is set")
+		}
+
+		// Close should be a no-op, not a panic, when there's no janitor.
+		if err := cache.Close(); err != nil {
+			t.Errorf("Expected Close to succeed, got %v", err)
+		}
+	})
+
+	t.Run("WithCleanupInterval overrides the default interval", func(t *testing.T) {
+		cache := New[string, int](WithCleanupInterval[string, int](10 * time.Millisecond))
+		defer cache.Close()
+
+		if cache.cleanupInterval != 10*time.Millisecond {
+			t.Errorf("Expected 10ms cleanup interval, got %v", cache.cleanupInterval)
+		}
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Run("Close is safe to call multiple times", func(t *testing.T) {
+		cache := New[string, int]()
+
+		if err := cache.Close(); err != nil {
+			t.Errorf("Expected first Close to succeed, got %v", err)
+		}
+		if err := cache.Close(); err != nil {
+			t.Errorf("Expected second Close to succeed, got %v", err)
+		}
+	})
+}
+
+func TestSharding(t *testing.T) {
+	t.Run("Keys route to shards consistently", func(t *testing.T) {
+		cache := New[string, int](WithShards[string, int](8))
+		defer cache.Close()
+
+		if cache.shardFor("key1") != cache.shardFor("key1") {
+			t.Error("Expected the same key to always route to the same shard")
+		}
 	})
 }
@@ -0,0 +1,157 @@
+package kache
+
+// EvictReason describes why an item left the cache.
+type EvictReason int
+
+const (
+	// ReasonExpired means the janitor removed the item because its expiry
+	// had passed. Expired items that Get or Pop drop on access are removed
+	// without firing eviction callbacks.
+	ReasonExpired EvictReason = iota
+	// ReasonCapacity means the item was evicted to make room under a
+	// WithMaxEntries limit.
+	ReasonCapacity
+	// ReasonManual means the item was removed by an explicit call to
+	// Delete.
+	ReasonManual
+)
+
+// String returns a human-readable name for the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case ReasonExpired:
+		return "expired"
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonManual:
+		return "manual"
+	default:
+		return "unknown"
+	}
+}
+
+// Evicted describes a single item that left the cache, for use with
+// WithOnEvictedBulk.
+type Evicted[K comparable, V any] struct {
+	Key    K
+	Value  V
+	Reason EvictReason
+}
+
+// WithMaxEntries turns the cache into a bounded LRU: once the number of
+// entries exceeds n, the least recently used item is evicted to make room.
+// Access via Get or Set counts as use. A value of 0 (the default) means
+// unbounded.
+func WithMaxEntries[K comparable, V any](n int) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.maxEntries = n
+	}
+}
+
+// WithOnEvicted registers a callback invoked once per item removed by a
+// janitor sweep, a capacity eviction, or Delete. It is not invoked for
+// items removed by Pop, or for expired items that Get or Pop drop on access.
+func WithOnEvicted[K comparable, V any](fn func(K, V, EvictReason)) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.onEvicted = fn
+	}
+}
+
+// WithOnEvictedBulk registers a callback invoked with every item evicted by
+// a single cleanup pass or Set call, as an alternative to WithOnEvicted for
+// callers who'd rather batch the work.
+func WithOnEvictedBulk[K comparable, V any](fn func([]Evicted[K, V])) Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.onEvictedBulk = fn
+	}
+}
+
+// touch records key as the most recently used entry and returns the keys
+// that overflow past maxEntries as a result, for the caller to pass to
+// evictOverflow.
+//
+// The caller must hold key's shard lock across the call, so that the LRU
+// list only ever reflects a key's presence in the shard's data at a single
+// consistent point in time; without that, a concurrent Set or Delete on the
+// same key could interleave with this update and leave the list out of
+// sync with what's actually in the shard. It must only be called when
+// c.maxEntries > 0.
+func (c *Kache[K, V]) touch(key K) []K {
+	c.lruMu.Lock()
+	defer c.lruMu.Unlock()
+
+	if e, ok := c.elems[key]; ok {
+		c.list.MoveToFront(e)
+	} else {
+		c.elems[key] = c.list.PushFront(key)
+	}
+
+	var overflow []K
+	for c.list.Len() > c.maxEntries {
+		back := c.list.Back()
+		if back == nil {
+			break
+		}
+		k := back.Value.(K)
+		c.list.Remove(back)
+		delete(c.elems, k)
+		overflow = append(overflow, k)
+	}
+	return overflow
+}
+
+// removeFromLRU drops key from the access-order list without evicting
+// anything else. As with touch, the caller must hold key's shard lock
+// across the call to keep the list in sync with the shard's data. It must
+// only be called when c.maxEntries > 0.
+func (c *Kache[K, V]) removeFromLRU(key K) {
+	c.lruMu.Lock()
+	defer c.lruMu.Unlock()
+	if e, ok := c.elems[key]; ok {
+		c.list.Remove(e)
+		delete(c.elems, key)
+	}
+}
+
+// evictOverflow removes the keys returned by touch from the cache and
+// fires eviction callbacks for the ones still present. Callers must invoke
+// it only after releasing the shard lock they held for touch, since it
+// acquires shard locks itself to delete each victim.
+func (c *Kache[K, V]) evictOverflow(overflow []K) {
+	if len(overflow) == 0 {
+		return
+	}
+
+	evicted := make([]Evicted[K, V], 0, len(overflow))
+	for _, k := range overflow {
+		s := c.shardFor(k)
+		s.mu.Lock()
+		it, found := s.data[k]
+		if found {
+			delete(s.data, k)
+		}
+		s.mu.Unlock()
+
+		if found {
+			evicted = append(evicted, Evicted[K, V]{Key: k, Value: it.value, Reason: ReasonCapacity})
+		}
+	}
+	c.fireEvicted(evicted)
+}
+
+// fireEvicted invokes the configured eviction callbacks, if any, for a
+// batch of items that just left the cache. Safe to call with an empty or
+// nil slice.
+func (c *Kache[K, V]) fireEvicted(evicted []Evicted[K, V]) {
+	if len(evicted) == 0 {
+		return
+	}
+	if c.onEvicted != nil {
+		for _, e := range evicted {
+			c.onEvicted(e.Key, e.Value, e.Reason)
+		}
+	}
+	if c.onEvictedBulk != nil {
+		c.onEvictedBulk(evicted)
+	}
+}
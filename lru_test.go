@@ -0,0 +1,196 @@
+package kache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUEviction(t *testing.T) {
+	t.Run("Evicts least recently used entry past capacity", func(t *testing.T) {
+		cache := New[string, int](WithMaxEntries[string, int](2))
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, time.Minute)
+		cache.Set("c", 3, time.Minute)
+
+		if _, ok := cache.Get("a"); ok {
+			t.Error("Expected 'a' to have been evicted")
+		}
+		if _, ok := cache.Get("b"); !ok {
+			t.Error("Expected 'b' to still be present")
+		}
+		if _, ok := cache.Get("c"); !ok {
+			t.Error("Expected 'c' to still be present")
+		}
+	})
+
+	t.Run("Get promotes an entry so it survives eviction", func(t *testing.T) {
+		cache := New[string, int](WithMaxEntries[string, int](2))
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, time.Minute)
+		cache.Get("a") // a is now most recently used
+		cache.Set("c", 3, time.Minute)
+
+		if _, ok := cache.Get("b"); ok {
+			t.Error("Expected 'b' to have been evicted as the least recently used")
+		}
+		if _, ok := cache.Get("a"); !ok {
+			t.Error("Expected 'a' to still be present after being promoted")
+		}
+	})
+
+	t.Run("Evicts the correct victim when combined with sharding", func(t *testing.T) {
+		// The LRU list is a single global structure shared across shards,
+		// so eviction order must still follow recency of use rather than
+		// which shard an entry happens to land in.
+		cache := New[string, int](WithShards[string, int](4), WithMaxEntries[string, int](2))
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, time.Minute)
+		cache.Get("a") // a is now most recently used, regardless of its shard
+		cache.Set("c", 3, time.Minute)
+
+		if _, ok := cache.Get("b"); ok {
+			t.Error("Expected 'b' to have been evicted as the least recently used")
+		}
+		if _, ok := cache.Get("a"); !ok {
+			t.Error("Expected 'a' to still be present after being promoted")
+		}
+		if _, ok := cache.Get("c"); !ok {
+			t.Error("Expected 'c' to still be present")
+		}
+	})
+}
+
+func TestOnEvicted(t *testing.T) {
+	t.Run("Fires once per capacity eviction with the right reason", func(t *testing.T) {
+		var gotKey string
+		var gotReason EvictReason
+
+		cache := New[string, int](
+			WithMaxEntries[string, int](1),
+			WithOnEvicted[string, int](func(k string, v int, reason EvictReason) {
+				gotKey = k
+				gotReason = reason
+			}),
+		)
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, time.Minute)
+
+		if gotKey != "a" {
+			t.Errorf("Expected 'a' to be evicted, got %q", gotKey)
+		}
+		if gotReason != ReasonCapacity {
+			t.Errorf("Expected ReasonCapacity, got %v", gotReason)
+		}
+	})
+
+	t.Run("Fires with ReasonManual on Delete", func(t *testing.T) {
+		var gotReason EvictReason
+
+		cache := New[string, int](
+			WithOnEvicted[string, int](func(k string, v int, reason EvictReason) {
+				gotReason = reason
+			}),
+		)
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Delete("a")
+
+		if gotReason != ReasonManual {
+			t.Errorf("Expected ReasonManual, got %v", gotReason)
+		}
+	})
+
+	t.Run("Does not fire when deleting a missing key", func(t *testing.T) {
+		fired := false
+
+		cache := New[string, int](
+			WithOnEvicted[string, int](func(k string, v int, reason EvictReason) {
+				fired = true
+			}),
+		)
+		defer cache.Close()
+
+		cache.Delete("missing")
+
+		if fired {
+			t.Error("Expected callback not to fire for a missing key")
+		}
+	})
+}
+
+func TestOnEvictedBulk(t *testing.T) {
+	t.Run("Receives capacity evictions as a batch", func(t *testing.T) {
+		var batches [][]Evicted[string, int]
+
+		cache := New[string, int](
+			WithMaxEntries[string, int](1),
+			WithOnEvictedBulk[string, int](func(evicted []Evicted[string, int]) {
+				batches = append(batches, evicted)
+			}),
+		)
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, time.Minute)
+
+		if len(batches) != 1 || len(batches[0]) != 1 {
+			t.Fatalf("Expected one batch of one eviction, got %v", batches)
+		}
+		if batches[0][0].Key != "a" || batches[0][0].Reason != ReasonCapacity {
+			t.Errorf("Unexpected eviction: %+v", batches[0][0])
+		}
+	})
+}
+
+func TestLRUConcurrentSetDelete(t *testing.T) {
+	t.Run("LRU tracking stays in sync with live shard entries under concurrent Set/Delete", func(t *testing.T) {
+		cache := New[string, int](WithShards[string, int](8), WithMaxEntries[string, int](1000))
+		defer cache.Close()
+
+		keys := make([]string, 20)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("key-%d", i)
+		}
+
+		var wg sync.WaitGroup
+		for g := 0; g < 200; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				k := keys[g%len(keys)]
+				cache.Set(k, g, time.Minute)
+				cache.Delete(k)
+			}(g)
+		}
+		wg.Wait()
+
+		cache.lruMu.Lock()
+		elemsLen, listLen := len(cache.elems), cache.list.Len()
+		cache.lruMu.Unlock()
+
+		live := 0
+		for _, s := range cache.shards {
+			s.mu.RLock()
+			live += len(s.data)
+			s.mu.RUnlock()
+		}
+
+		if elemsLen != listLen {
+			t.Fatalf("LRU list and elems diverged: elems=%d list=%d", elemsLen, listLen)
+		}
+		if elemsLen != live {
+			t.Fatalf("LRU tracking out of sync with live shard entries: elems=%d live=%d", elemsLen, live)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package kache
+
+import "time"
+
+// Number is the set of numeric types Increment and Decrement can operate
+// on. It's spelled out inline rather than pulled from
+// golang.org/x/exp/constraints, since this module has no other
+// dependencies.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Increment adds delta to the value stored at key and returns the new
+// value. The read-modify-write happens under the owning shard's lock, so
+// it's atomic with respect to concurrent Set, Delete and other
+// Increment/Decrement calls on the same key. The item's existing expiry is
+// left untouched. Returns ErrKeyNotFound if the key is missing or expired.
+func Increment[K comparable, V Number](c *Kache[K, V], key K, delta V) (V, error) {
+	s := c.shardFor(key)
+
+	s.mu.Lock()
+	it, found := s.data[key]
+	if !found || it.isExpired() {
+		s.mu.Unlock()
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+	it.value += delta
+	s.data[key] = it
+	var overflow []K
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+	return it.value, nil
+}
+
+// Decrement subtracts delta from the value stored at key and returns the
+// new value. See Increment for its atomicity and error semantics.
+func Decrement[K comparable, V Number](c *Kache[K, V], key K, delta V) (V, error) {
+	return Increment(c, key, -delta)
+}
+
+// SetIfAbsent sets value for key only if it is not already present and
+// unexpired, returning true if the value was set. It's typically used to
+// seed a counter before the first Increment or Decrement.
+func (c *Kache[K, V]) SetIfAbsent(key K, value V, expiry time.Duration) bool {
+	return c.Add(key, value, expiry) == nil
+}
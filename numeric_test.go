@@ -0,0 +1,143 @@
+package kache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIncrement(t *testing.T) {
+	t.Run("Adds delta to the stored value", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("counter", 10, time.Minute)
+		v, err := Increment(cache, "counter", 5)
+		if err != nil {
+			t.Fatalf("Expected Increment to succeed, got %v", err)
+		}
+		if v != 15 {
+			t.Errorf("Expected 15, got %d", v)
+		}
+	})
+
+	t.Run("Preserves the existing expiry", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("counter", 1, time.Minute)
+		_, before, _ := cache.GetWithExpiration("counter")
+
+		if _, err := Increment(cache, "counter", 1); err != nil {
+			t.Fatalf("Expected Increment to succeed, got %v", err)
+		}
+
+		_, after, _ := cache.GetWithExpiration("counter")
+		if diff := after.Sub(before); diff < -time.Millisecond || diff > time.Millisecond {
+			t.Errorf("Expected expiry to be preserved, got %v want %v", after, before)
+		}
+	})
+
+	t.Run("Fails for a missing key", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if _, err := Increment(cache, "missing", 1); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Fails for an expired key", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("counter", 1, 10*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		if _, err := Increment(cache, "counter", 1); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Works with float values", func(t *testing.T) {
+		cache := New[string, float64]()
+		defer cache.Close()
+
+		cache.Set("total", 1.5, time.Minute)
+		v, err := Increment(cache, "total", 2.25)
+		if err != nil {
+			t.Fatalf("Expected Increment to succeed, got %v", err)
+		}
+		if v != 3.75 {
+			t.Errorf("Expected 3.75, got %v", v)
+		}
+	})
+}
+
+func TestDecrement(t *testing.T) {
+	t.Run("Subtracts delta from the stored value", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("counter", 10, time.Minute)
+		v, err := Decrement(cache, "counter", 3)
+		if err != nil {
+			t.Fatalf("Expected Decrement to succeed, got %v", err)
+		}
+		if v != 7 {
+			t.Errorf("Expected 7, got %d", v)
+		}
+	})
+}
+
+func TestSetIfAbsent(t *testing.T) {
+	t.Run("Seeds a counter only once", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if !cache.SetIfAbsent("counter", 0, time.Minute) {
+			t.Error("Expected the first SetIfAbsent to succeed")
+		}
+		if cache.SetIfAbsent("counter", 100, time.Minute) {
+			t.Error("Expected the second SetIfAbsent to fail")
+		}
+
+		v, _ := cache.Get("counter")
+		if v != 0 {
+			t.Errorf("Expected the seeded value 0 to survive, got %d", v)
+		}
+	})
+}
+
+func TestIncrementConcurrency(t *testing.T) {
+	t.Run("Increment is atomic under concurrent access", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.SetIfAbsent("counter", 0, time.Minute)
+
+		var wg sync.WaitGroup
+		workers := 50
+		incrementsPerWorker := 200
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < incrementsPerWorker; j++ {
+					if _, err := Increment(cache, "counter", 1); err != nil {
+						t.Errorf("Unexpected Increment error: %v", err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		v, _ := cache.Get("counter")
+		want := workers * incrementsPerWorker
+		if v != want {
+			t.Errorf("Expected %d, got %d", want, v)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package kache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Item is a point-in-time snapshot of a single cache entry, as produced by
+// Save/SaveFile and consumed by Load, LoadFile and NewFrom.
+//
+// If V (or K) is an interface type, callers must gob.Register the concrete
+// types stored in the cache before calling Save or Load, or gob will fail
+// to encode/decode them.
+type Item[V any] struct {
+	Value  V
+	Expiry time.Time
+}
+
+// WithMergeOnLoad makes Load and LoadFile skip keys that are already
+// present and unexpired in the cache, instead of overwriting them with the
+// snapshot's value.
+func WithMergeOnLoad[K comparable, V any]() Option[K, V] {
+	return func(c *Kache[K, V]) {
+		c.mergeOnLoad = true
+	}
+}
+
+// NewFrom creates a new Kache pre-populated with items, such as a map
+// produced by Save/Load. Entries that are already expired are skipped.
+func NewFrom[K comparable, V any](items map[K]Item[V], opts ...Option[K, V]) *Kache[K, V] {
+	c := New[K, V](opts...)
+	for k, it := range items {
+		c.restore(k, item[V]{value: it.Value, expiry: it.Expiry})
+	}
+	return c
+}
+
+// restore inserts it into the cache unless it has already expired,
+// honoring mergeOnLoad and the LRU bookkeeping.
+func (c *Kache[K, V]) restore(key K, it item[V]) {
+	if it.isExpired() {
+		return
+	}
+
+	s := c.shardFor(key)
+	s.mu.Lock()
+	if c.mergeOnLoad {
+		if existing, found := s.data[key]; found && !existing.isExpired() {
+			s.mu.Unlock()
+			return
+		}
+	}
+	s.data[key] = it
+	var overflow []K
+	if c.maxEntries > 0 {
+		overflow = c.touch(key)
+	}
+	s.mu.Unlock()
+
+	c.evictOverflow(overflow)
+}
+
+// Save writes every unexpired item in the cache to w, gob-encoded.
+func (c *Kache[K, V]) Save(w io.Writer) error {
+	snapshot := make(map[K]Item[V])
+	for _, s := range c.shards {
+		s.mu.RLock()
+		for k, it := range s.data {
+			if it.isExpired() {
+				continue
+			}
+			snapshot[k] = Item[V]{Value: it.value, Expiry: it.expiry}
+		}
+		s.mu.RUnlock()
+	}
+
+	if err := gob.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("kache: encode cache: %w", err)
+	}
+	return nil
+}
+
+// SaveFile writes the cache's contents to path, creating or truncating it.
+func (c *Kache[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("kache: create cache file: %w", err)
+	}
+	defer f.Close()
+
+	if err := c.Save(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads items gob-encoded by Save from r and adds them to the cache.
+// By default it overwrites any existing keys; pass WithMergeOnLoad to New
+// to instead keep live local entries over the loaded ones.
+func (c *Kache[K, V]) Load(r io.Reader) error {
+	var snapshot map[K]Item[V]
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("kache: decode cache: %w", err)
+	}
+
+	for k, it := range snapshot {
+		c.restore(k, item[V]{value: it.Value, expiry: it.Expiry})
+	}
+	return nil
+}
+
+// LoadFile reads items gob-encoded by SaveFile from path and adds them to
+// the cache.
+func (c *Kache[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("kache: open cache file: %w", err)
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
@@ -0,0 +1,222 @@
+package kache
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	t.Run("Primitive values", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("a", 1, time.Minute)
+		cache.Set("b", 2, NoExpiration)
+
+		var buf bytes.Buffer
+		if err := cache.Save(&buf); err != nil {
+			t.Fatalf("Expected Save to succeed, got %v", err)
+		}
+
+		restored := New[string, int]()
+		defer restored.Close()
+		if err := restored.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+
+		if v, ok := restored.Get("a"); !ok || v != 1 {
+			t.Errorf("Expected a=1, got %v %v", v, ok)
+		}
+		if v, ok := restored.Get("b"); !ok || v != 2 {
+			t.Errorf("Expected b=2, got %v %v", v, ok)
+		}
+	})
+
+	t.Run("Struct values", func(t *testing.T) {
+		type User struct {
+			Name  string
+			Email string
+		}
+
+		cache := New[string, User]()
+		defer cache.Close()
+		cache.Set("user1", User{Name: "John", Email: "john@example.com"}, time.Minute)
+
+		var buf bytes.Buffer
+		if err := cache.Save(&buf); err != nil {
+			t.Fatalf("Expected Save to succeed, got %v", err)
+		}
+
+		restored := New[string, User]()
+		defer restored.Close()
+		if err := restored.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+
+		user, ok := restored.Get("user1")
+		if !ok || user.Name != "John" || user.Email != "john@example.com" {
+			t.Errorf("Unexpected user after round-trip: %+v %v", user, ok)
+		}
+	})
+
+	t.Run("Pointer values", func(t *testing.T) {
+		type Counter struct {
+			N int
+		}
+
+		cache := New[string, *Counter]()
+		defer cache.Close()
+		cache.Set("c1", &Counter{N: 7}, time.Minute)
+
+		var buf bytes.Buffer
+		if err := cache.Save(&buf); err != nil {
+			t.Fatalf("Expected Save to succeed, got %v", err)
+		}
+
+		restored := New[string, *Counter]()
+		defer restored.Close()
+		if err := restored.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+
+		counter, ok := restored.Get("c1")
+		if !ok || counter == nil || counter.N != 7 {
+			t.Errorf("Unexpected counter after round-trip: %+v %v", counter, ok)
+		}
+	})
+
+	t.Run("Expired items are not saved", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		cache.Set("a", 1, 10*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		var buf bytes.Buffer
+		if err := cache.Save(&buf); err != nil {
+			t.Fatalf("Expected Save to succeed, got %v", err)
+		}
+
+		restored := New[string, int]()
+		defer restored.Close()
+		if err := restored.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+
+		if _, ok := restored.Get("a"); ok {
+			t.Error("Expected expired item not to be restored")
+		}
+	})
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	t.Run("Round-trips through a file on disk", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+		cache.Set("a", 1, time.Minute)
+
+		path := filepath.Join(t.TempDir(), "cache.gob")
+		if err := cache.SaveFile(path); err != nil {
+			t.Fatalf("Expected SaveFile to succeed, got %v", err)
+		}
+
+		restored := New[string, int]()
+		defer restored.Close()
+		if err := restored.LoadFile(path); err != nil {
+			t.Fatalf("Expected LoadFile to succeed, got %v", err)
+		}
+
+		if v, ok := restored.Get("a"); !ok || v != 1 {
+			t.Errorf("Expected a=1, got %v %v", v, ok)
+		}
+	})
+
+	t.Run("LoadFile surfaces a clear error for a missing file", func(t *testing.T) {
+		cache := New[string, int]()
+		defer cache.Close()
+
+		if err := cache.LoadFile(filepath.Join(t.TempDir(), "missing.gob")); err == nil {
+			t.Error("Expected LoadFile to return an error for a missing file")
+		} else if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected a not-exist error, got %v", err)
+		}
+	})
+}
+
+func TestWithMergeOnLoad(t *testing.T) {
+	t.Run("Load overwrites existing keys by default", func(t *testing.T) {
+		source := New[string, int]()
+		defer source.Close()
+		source.Set("a", 99, time.Minute)
+
+		var buf bytes.Buffer
+		source.Save(&buf)
+
+		target := New[string, int]()
+		defer target.Close()
+		target.Set("a", 1, time.Minute)
+
+		if err := target.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+		if v, _ := target.Get("a"); v != 99 {
+			t.Errorf("Expected Load to overwrite with 99, got %v", v)
+		}
+	})
+
+	t.Run("WithMergeOnLoad keeps the live local value", func(t *testing.T) {
+		source := New[string, int]()
+		defer source.Close()
+		source.Set("a", 99, time.Minute)
+
+		var buf bytes.Buffer
+		source.Save(&buf)
+
+		target := New[string, int](WithMergeOnLoad[string, int]())
+		defer target.Close()
+		target.Set("a", 1, time.Minute)
+
+		if err := target.Load(&buf); err != nil {
+			t.Fatalf("Expected Load to succeed, got %v", err)
+		}
+		if v, _ := target.Get("a"); v != 1 {
+			t.Errorf("Expected merge to keep the live value 1, got %v", v)
+		}
+	})
+}
+
+func TestNewFrom(t *testing.T) {
+	t.Run("Populates a new cache from a snapshot", func(t *testing.T) {
+		items := map[string]Item[int]{
+			"a": {Value: 1, Expiry: time.Now().Add(time.Minute)},
+			"b": {Value: 2},
+		}
+
+		cache := NewFrom(items)
+		defer cache.Close()
+
+		if v, ok := cache.Get("a"); !ok || v != 1 {
+			t.Errorf("Expected a=1, got %v %v", v, ok)
+		}
+		if v, ok := cache.Get("b"); !ok || v != 2 {
+			t.Errorf("Expected b=2, got %v %v", v, ok)
+		}
+	})
+
+	t.Run("Skips already-expired entries", func(t *testing.T) {
+		items := map[string]Item[int]{
+			"a": {Value: 1, Expiry: time.Now().Add(-time.Minute)},
+		}
+
+		cache := NewFrom(items)
+		defer cache.Close()
+
+		if _, ok := cache.Get("a"); ok {
+			t.Error("Expected expired entry to be skipped")
+		}
+	})
+}